@@ -0,0 +1,60 @@
+package token_provider
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGetAuxiliaryTokens_NoRaceWithConcurrentRefresh(t *testing.T) {
+	aux := &auxiliaryToken{tenantID: "aux-tenant"}
+	tp := &tokenProvider{auxiliaryTokens: []*auxiliaryToken{aux}}
+
+	stop := make(chan struct{})
+	var writes int64
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			n := atomic.AddInt64(&writes, 1)
+			aux.mu.Lock()
+			aux.token = fmt.Sprintf("aux-token-%d", n)
+			aux.lastError = nil
+			aux.mu.Unlock()
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 2000; i++ {
+			if _, err := tp.GetAuxiliaryTokens(); err != nil {
+				t.Errorf("GetAuxiliaryTokens: unexpected error: %v", err)
+				close(stop)
+				return
+			}
+		}
+		close(stop)
+	}()
+
+	wg.Wait()
+}
+
+func TestGetAuxiliaryTokens_ReturnsRefreshError(t *testing.T) {
+	aux := &auxiliaryToken{tenantID: "aux-tenant"}
+	aux.lastError = errors.New("auxiliary refresh failed")
+	tp := &tokenProvider{auxiliaryTokens: []*auxiliaryToken{aux}}
+
+	if _, err := tp.GetAuxiliaryTokens(); err == nil {
+		t.Fatal("GetAuxiliaryTokens: expected error from a tenant with a failed refresh")
+	}
+}
@@ -0,0 +1,24 @@
+package token_provider
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextRefreshBackoff_StaysWithinBounds(t *testing.T) {
+	previous := time.Duration(0)
+	for i := 0; i < 200; i++ {
+		next := nextRefreshBackoff(previous)
+		if next < refreshRetryBackoffBase || next > refreshRetryBackoffCap {
+			t.Fatalf("nextRefreshBackoff(%v) = %v, want within [%v, %v]", previous, next, refreshRetryBackoffBase, refreshRetryBackoffCap)
+		}
+		previous = next
+	}
+}
+
+func TestNextRefreshBackoff_CapsAtMaximum(t *testing.T) {
+	next := nextRefreshBackoff(time.Hour)
+	if next > refreshRetryBackoffCap {
+		t.Fatalf("nextRefreshBackoff(time.Hour) = %v, want <= %v", next, refreshRetryBackoffCap)
+	}
+}
@@ -0,0 +1,59 @@
+package token_provider
+
+import (
+	"crypto/sha256"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSaveAndLoadTokenCache_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token-cache.enc")
+	key := sha256.Sum256([]byte("test-encryption-key"))
+
+	entry := &cachedTokenEntry{
+		Token:     "cached-token",
+		ExpiresOn: time.Now().Add(time.Hour).UTC().Truncate(time.Second),
+		Audience:  "https://example.com/.default",
+		TenantId:  "tenant-a",
+	}
+
+	if err := saveTokenCache(path, key[:], entry); err != nil {
+		t.Fatalf("saveTokenCache: %v", err)
+	}
+
+	if _, err := loadTokenCache(path+".tmp", key[:]); err == nil {
+		t.Fatal("saveTokenCache: expected the .tmp file to be renamed away, not left behind")
+	}
+
+	loaded, err := loadTokenCache(path, key[:])
+	if err != nil {
+		t.Fatalf("loadTokenCache: %v", err)
+	}
+
+	if loaded.Token != entry.Token ||
+		!loaded.ExpiresOn.Equal(entry.ExpiresOn) ||
+		loaded.Audience != entry.Audience ||
+		loaded.TenantId != entry.TenantId {
+		t.Fatalf("round-tripped entry = %+v, want %+v", loaded, entry)
+	}
+}
+
+func TestLoadTokenCache_WrongKeyFails(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token-cache.enc")
+	rightKey := sha256.Sum256([]byte("right-key"))
+	wrongKey := sha256.Sum256([]byte("wrong-key"))
+
+	entry := &cachedTokenEntry{
+		Token:     "cached-token",
+		ExpiresOn: time.Now().Add(time.Hour),
+		Audience:  "https://example.com/.default",
+	}
+	if err := saveTokenCache(path, rightKey[:], entry); err != nil {
+		t.Fatalf("saveTokenCache: %v", err)
+	}
+
+	if _, err := loadTokenCache(path, wrongKey[:]); err == nil {
+		t.Fatal("loadTokenCache: expected decryption to fail with the wrong key")
+	}
+}
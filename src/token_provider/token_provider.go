@@ -5,8 +5,16 @@ import (
 	"aad-auth-proxy/contracts"
 	"aad-auth-proxy/utils"
 	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
 	"errors"
-	"sync/atomic"
+	"math/rand"
+	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
@@ -15,31 +23,129 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
 )
 
+// Supported values for the AAD_AUTH_MODE configuration key, selecting which
+// azidentity credential backs the token provider.
+const (
+	AuthModeWorkloadIdentity  = "workload_identity"
+	AuthModeManagedIdentity   = "msi"
+	AuthModeClientSecret      = "client_secret"
+	AuthModeClientCertificate = "client_certificate"
+	AuthModeAzureCli          = "cli"
+	AuthModeGithubOidc        = "github_oidc"
+)
+
+// Bounds for the decorrelated-jitter backoff used when a token refresh fails
+// and needs to be retried: backoff = min(cap, random_between(base, prev*3)).
+const (
+	refreshRetryBackoffBase = 5 * time.Second
+	refreshRetryBackoffCap  = 5 * time.Minute
+)
+
+// nextRefreshBackoff computes the next retry delay using decorrelated jitter,
+// seeded from the previous delay so consecutive failures spread out instead
+// of retrying in lockstep with other replicas hitting the same AAD outage.
+func nextRefreshBackoff(previous time.Duration) time.Duration {
+	if previous < refreshRetryBackoffBase {
+		previous = refreshRetryBackoffBase
+	}
+	upper := previous * 3
+	if upper > refreshRetryBackoffCap {
+		upper = refreshRetryBackoffCap
+	}
+
+	jittered := refreshRetryBackoffBase
+	if span := upper - refreshRetryBackoffBase; span > 0 {
+		jittered += time.Duration(rand.Int63n(int64(span) + 1))
+	}
+	if jittered > refreshRetryBackoffCap {
+		jittered = refreshRetryBackoffCap
+	}
+	return jittered
+}
+
+// auxiliaryToken tracks the refresh state for a single auxiliary tenant's
+// token, used to authorize cross-tenant requests alongside the primary
+// token. Its own refresh goroutine writes token/lastError/expiresOn while
+// GetAuxiliaryTokens reads them from request-handling goroutines, so every
+// access must go through mu.
+type auxiliaryToken struct {
+	tenantID        string
+	mu              sync.Mutex
+	token           string
+	lastError       error
+	expiresOn       time.Time
+	refreshDuration time.Duration
+	options         *policy.TokenRequestOptions
+}
+
+// tokenProvider caches the primary token behind a sync.Cond-guarded record,
+// mirroring azidentity's expiringResource: GetAccessToken serves the cached
+// token directly while it is valid, kicks off a proactive refresh in the
+// background once past its configured refresh point (proactiveRefreshAt,
+// derived from userConfiguredDurationPercentage), and single-flights a synchronous
+// refresh (callers wait on the cond variable rather than racing the
+// credential) once the cached token is missing or hard-expired.
 type tokenProvider struct {
-	token                            string
 	ctx                              context.Context
+	mu                               sync.Mutex
+	cond                             *sync.Cond
+	acquiring                        bool
+	token                            string
+	expiresOn                        time.Time
+	proactiveRefreshAt               time.Time
 	lastError                        error
 	userConfiguredDurationPercentage uint8
-	refreshDuration                  time.Duration
 	credentialClient                 azcore.TokenCredential
 	options                          *policy.TokenRequestOptions
+	auxiliaryTokens                  []*auxiliaryToken
+	audience                         string
+	tenantId                         string
+	tokenCachePath                   string
+	tokenCacheKey                    []byte
+}
+
+// cachedTokenEntry is the on-disk representation of the primary token, used
+// to survive proxy restarts without forcing a new AAD round-trip.
+type cachedTokenEntry struct {
+	Token     string    `json:"token"`
+	ExpiresOn time.Time `json:"expiresOn"`
+	Audience  string    `json:"audience"`
+	TenantId  string    `json:"tenantId,omitempty"`
 }
 
-func NewTokenProvider(audience string, config utils.IConfiguration, logger contracts.ILogger) (contracts.ITokenProvider, error) {
+func NewTokenProvider(audience string, auxiliaryTenantIds []string, config utils.IConfiguration, logger contracts.ILogger) (contracts.ITokenProvider, error) {
 	if config == nil || logger == nil {
 		return nil, errors.New("NewTokenProvider: Required arguments canot be nil")
 	}
 
 	userConfiguredDurationPercentage := config.GetAadTokenRefreshDurationInPercentage()
 
-	cred, err := azidentity.NewDefaultAzureCredential(nil)
-
+	cred, err := newCredential(config, auxiliaryTenantIds, logger)
 	if err != nil {
 		return nil, err
 	}
 
+	auxiliaryTokens := make([]*auxiliaryToken, 0, len(auxiliaryTenantIds))
+	for _, tenantId := range auxiliaryTenantIds {
+		auxiliaryTokens = append(auxiliaryTokens, &auxiliaryToken{
+			tenantID: tenantId,
+			options:  &policy.TokenRequestOptions{Scopes: []string{audience}, TenantID: tenantId},
+		})
+	}
+
+	tokenCachePath := config.GetTokenCacheFilePath()
+	var tokenCacheKey []byte
+	if tokenCachePath != "" {
+		tokenCacheKey, err = tokenCacheEncryptionKey(config)
+		if err != nil {
+			logger.Information("NewTokenProvider: failed to derive token cache encryption key, disk cache disabled: " + err.Error())
+			tokenCachePath = ""
+		}
+	}
+
 	tokenProvider := &tokenProvider{
 		ctx:                              context.Background(),
 		token:                            "",
@@ -47,24 +153,424 @@ func NewTokenProvider(audience string, config utils.IConfiguration, logger contr
 		userConfiguredDurationPercentage: userConfiguredDurationPercentage,
 		credentialClient:                 cred,
 		options:                          &policy.TokenRequestOptions{Scopes: []string{audience}},
+		auxiliaryTokens:                  auxiliaryTokens,
+		audience:                         audience,
+		tenantId:                         config.GetAadTenantId(),
+		tokenCachePath:                   tokenCachePath,
+		tokenCacheKey:                    tokenCacheKey,
 	}
+	tokenProvider.cond = sync.NewCond(&tokenProvider.mu)
 
-	err = tokenProvider.refreshAADToken()
-	if err != nil {
-		return nil, errors.New("Failed to get access token: " + err.Error())
+	if !tokenProvider.loadTokenFromDiskCache(logger) {
+		err = tokenProvider.refreshAADToken(tokenProvider.ctx)
+		if err != nil {
+			return nil, errors.New("Failed to get access token: " + err.Error())
+		}
+	}
+
+	for _, aux := range tokenProvider.auxiliaryTokens {
+		if err := tokenProvider.refreshAuxiliaryToken(aux); err != nil {
+			return nil, errors.New("Failed to get auxiliary access token for tenant " + aux.tenantID + ": " + err.Error())
+		}
+	}
+
+	for _, aux := range tokenProvider.auxiliaryTokens {
+		go tokenProvider.periodicallyRefreshAuxiliaryToken(aux, logger)
 	}
 
-	go tokenProvider.periodicallyRefreshClientToken(logger)
 	return tokenProvider, nil
 }
 
+// newCredential builds the azcore.TokenCredential to use based on the
+// AAD_AUTH_MODE configuration key, mirroring the set of authorizers
+// hashicorp/go-azure-sdk exposes. It falls back to DefaultAzureCredential's
+// chain when no mode (or an unrecognized one) is configured.
+func newCredential(config utils.IConfiguration, auxiliaryTenantIds []string, logger contracts.ILogger) (azcore.TokenCredential, error) {
+	authMode := config.GetAadAuthMode()
+
+	switch authMode {
+	case AuthModeWorkloadIdentity:
+		logger.Information("newCredential: using workload identity credential")
+		return azidentity.NewWorkloadIdentityCredential(&azidentity.WorkloadIdentityCredentialOptions{
+			AdditionallyAllowedTenants: auxiliaryTenantIds,
+		})
+
+	case AuthModeManagedIdentity:
+		logger.Information("newCredential: using managed identity credential")
+		options := &azidentity.ManagedIdentityCredentialOptions{}
+		if clientId := config.GetAadClientId(); clientId != "" {
+			options.ID = azidentity.ClientID(clientId)
+		}
+		return azidentity.NewManagedIdentityCredential(options)
+
+	case AuthModeClientSecret:
+		logger.Information("newCredential: using client secret credential")
+		return azidentity.NewClientSecretCredential(config.GetAadTenantId(), config.GetAadClientId(), config.GetAadClientSecret(), &azidentity.ClientSecretCredentialOptions{
+			AdditionallyAllowedTenants: auxiliaryTenantIds,
+		})
+
+	case AuthModeClientCertificate:
+		logger.Information("newCredential: using client certificate credential")
+		certData, err := os.ReadFile(config.GetAadClientCertificatePath())
+		if err != nil {
+			return nil, errors.New("newCredential: failed to read client certificate: " + err.Error())
+		}
+		certs, key, err := azidentity.ParseCertificates(certData, nil)
+		if err != nil {
+			return nil, errors.New("newCredential: failed to parse client certificate: " + err.Error())
+		}
+		return azidentity.NewClientCertificateCredential(config.GetAadTenantId(), config.GetAadClientId(), certs, key, &azidentity.ClientCertificateCredentialOptions{
+			AdditionallyAllowedTenants: auxiliaryTenantIds,
+		})
+
+	case AuthModeAzureCli:
+		logger.Information("newCredential: using Azure CLI credential")
+		return azidentity.NewAzureCLICredential(&azidentity.AzureCLICredentialOptions{
+			AdditionallyAllowedTenants: auxiliaryTenantIds,
+		})
+
+	case AuthModeGithubOidc:
+		logger.Information("newCredential: using federated GitHub OIDC credential")
+		return azidentity.NewClientAssertionCredential(config.GetAadTenantId(), config.GetAadClientId(), func(ctx context.Context) (string, error) {
+			tokenFile := config.GetAadFederatedTokenFilePath()
+			token, err := os.ReadFile(tokenFile)
+			if err != nil {
+				return "", errors.New("newCredential: failed to read federated token file: " + err.Error())
+			}
+			return strings.TrimSpace(string(token)), nil
+		}, &azidentity.ClientAssertionCredentialOptions{
+			AdditionallyAllowedTenants: auxiliaryTenantIds,
+		})
+
+	default:
+		logger.Information("newCredential: AAD_AUTH_MODE not set or not recognized, using default Azure credential chain")
+		return azidentity.NewDefaultAzureCredential(&azidentity.DefaultAzureCredentialOptions{
+			AdditionallyAllowedTenants: auxiliaryTenantIds,
+		})
+	}
+}
+
+// loadTokenFromDiskCache attempts to seed the in-memory cache from the
+// encrypted disk cache so that a restart doesn't force an immediate AAD
+// round-trip. It reports whether a still-valid token for the configured
+// audience was loaded.
+func (tokenProvider *tokenProvider) loadTokenFromDiskCache(logger contracts.ILogger) bool {
+	if tokenProvider.tokenCachePath == "" {
+		return false
+	}
+
+	entry, err := loadTokenCache(tokenProvider.tokenCachePath, tokenProvider.tokenCacheKey)
+	if err != nil {
+		return false
+	}
+
+	if entry.Audience != tokenProvider.audience || !time.Now().UTC().Before(entry.ExpiresOn) {
+		return false
+	}
+
+	tokenProvider.token = entry.Token
+	tokenProvider.expiresOn = entry.ExpiresOn
+	tokenProvider.proactiveRefreshAt = tokenProvider.getRefreshDuration(azcore.AccessToken{Token: entry.Token, ExpiresOn: entry.ExpiresOn})
+
+	meter := otel.Meter(constants.SERVICE_TELEMETRY_KEY)
+	cacheHitCounter, _ := meter.Int64Counter(constants.METRIC_TOKEN_CACHE_HIT_TOTAL)
+	cacheHitCounter.Add(tokenProvider.ctx, 1, metric.WithAttributes(attribute.String("source", "disk")))
+	logger.Information("NewTokenProvider: reused cached token for audience " + tokenProvider.audience + " from disk cache")
+
+	return true
+}
+
+// persistTokenToDiskCache writes the current token to the encrypted disk
+// cache, atomically (write-temp+rename) so a crash mid-write never leaves a
+// corrupt cache file behind.
+func (tokenProvider *tokenProvider) persistTokenToDiskCache() error {
+	if tokenProvider.tokenCachePath == "" {
+		return nil
+	}
+
+	return saveTokenCache(tokenProvider.tokenCachePath, tokenProvider.tokenCacheKey, &cachedTokenEntry{
+		Token:     tokenProvider.token,
+		ExpiresOn: tokenProvider.expiresOn,
+		Audience:  tokenProvider.audience,
+		TenantId:  tokenProvider.tenantId,
+	})
+}
+
+// tokenCacheEncryptionKey derives the AES-256 key used to encrypt the disk
+// token cache: a user-supplied key takes precedence, otherwise one is
+// derived from the machine identity so the cache is only portable within the
+// host (or pod) that created it.
+func tokenCacheEncryptionKey(config utils.IConfiguration) ([]byte, error) {
+	if userKey := config.GetTokenCacheEncryptionKey(); userKey != "" {
+		key := sha256.Sum256([]byte(userKey))
+		return key[:], nil
+	}
+
+	machineId, err := machineIdentifier()
+	if err != nil {
+		return nil, err
+	}
+	key := sha256.Sum256([]byte(machineId))
+	return key[:], nil
+}
+
+// machineIdentifier returns a stable identifier for the host (or pod), used
+// as key material when no explicit cache encryption key is configured.
+func machineIdentifier() (string, error) {
+	if id, err := os.ReadFile("/etc/machine-id"); err == nil {
+		return strings.TrimSpace(string(id)), nil
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", err
+	}
+	return hostname, nil
+}
+
+// loadTokenCache reads and decrypts a cachedTokenEntry from path.
+func loadTokenCache(path string, key []byte) (*cachedTokenEntry, error) {
+	ciphertext, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newTokenCacheCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("loadTokenCache: cache file is corrupt")
+	}
+	nonce, sealed := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+
+	plaintext, err := gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry cachedTokenEntry
+	if err := json.Unmarshal(plaintext, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// saveTokenCache encrypts and atomically writes entry to path.
+func saveTokenCache(path string, key []byte, entry *cachedTokenEntry) error {
+	plaintext, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	gcm, err := newTokenCacheCipher(key)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := cryptorand.Read(nonce); err != nil {
+		return err
+	}
+	ciphertext := gcm.Seal(nonce, nonce, plaintext, nil)
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, ciphertext, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+func newTokenCacheCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// GetAccessToken returns the cached token if it is still valid, kicking off a
+// background refresh once past its proactive refresh point (proactiveRefreshAt). If the
+// cached token is missing or hard-expired, it single-flights a synchronous
+// refresh: the caller that finds acquiring == false performs it, while
+// concurrent callers wait on the cond variable instead of each making their
+// own credential call.
 func (tokenProvider *tokenProvider) GetAccessToken() (string, error) {
-	return tokenProvider.token, tokenProvider.lastError
+	tokenProvider.mu.Lock()
+
+	now := time.Now().UTC()
+	if tokenProvider.hasValidTokenLocked(now) {
+		if now.After(tokenProvider.proactiveRefreshAt) && !tokenProvider.acquiring {
+			tokenProvider.acquiring = true
+			go tokenProvider.refreshInBackground()
+		}
+		// A still-valid cached token is always returned without error, even if
+		// a proactive/background refresh most recently failed: lastError only
+		// describes the refresh attempt, not the usability of the cached token.
+		token := tokenProvider.token
+		tokenProvider.mu.Unlock()
+		return token, nil
+	}
+
+	for tokenProvider.acquiring {
+		tokenProvider.cond.Wait()
+	}
+	if tokenProvider.hasValidTokenLocked(time.Now().UTC()) {
+		token := tokenProvider.token
+		tokenProvider.mu.Unlock()
+		return token, nil
+	}
+
+	tokenProvider.acquiring = true
+	tokenProvider.mu.Unlock()
+
+	err := tokenProvider.refreshAADToken(tokenProvider.ctx)
+
+	tokenProvider.mu.Lock()
+	tokenProvider.acquiring = false
+	tokenProvider.cond.Broadcast()
+	token := tokenProvider.token
+	tokenProvider.mu.Unlock()
+
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// hasValidTokenLocked reports whether the cached token is present and not
+// hard-expired. Callers must hold tokenProvider.mu.
+func (tokenProvider *tokenProvider) hasValidTokenLocked(now time.Time) bool {
+	return tokenProvider.token != "" && now.Before(tokenProvider.expiresOn)
+}
+
+// refreshInBackground performs a proactive refresh without blocking any
+// caller. On failure it keeps retrying with decorrelated-jitter backoff,
+// bounded by the still-cached token's remaining validity, rather than giving
+// up after one attempt - the cached token is still usable in the meantime.
+func (tokenProvider *tokenProvider) refreshInBackground() {
+	defer utils.HandlePanic("refreshInBackground")
+
+	meter := otel.Meter(constants.SERVICE_TELEMETRY_KEY)
+	retryCounter, _ := meter.Int64Counter(constants.METRIC_TOKEN_REFRESH_RETRY_TOTAL)
+
+	backoff := time.Duration(0)
+	attempt := 0
+	for {
+		err := tokenProvider.refreshAADToken(tokenProvider.ctx)
+		if err == nil {
+			break
+		}
+
+		tokenProvider.mu.Lock()
+		remaining := time.Until(tokenProvider.expiresOn)
+		tokenProvider.mu.Unlock()
+		if remaining <= 0 {
+			// The cached token is already hard-expired; let the next
+			// GetAccessToken call single-flight a synchronous refresh
+			// instead of retrying forever in the background.
+			break
+		}
+
+		attempt++
+		backoff = nextRefreshBackoff(backoff)
+		if backoff > remaining {
+			backoff = remaining
+		}
+
+		_, span := otel.Tracer(constants.SERVICE_TELEMETRY_KEY).Start(tokenProvider.ctx, "refreshAADToken.retry")
+		span.SetAttributes(
+			attribute.Int("attempt", attempt),
+			attribute.String("backoff_duration", backoff.String()),
+		)
+		span.RecordError(err)
+		span.End()
+		retryCounter.Add(tokenProvider.ctx, 1, metric.WithAttributes(attribute.Int("attempt", attempt)))
+
+		time.Sleep(backoff)
+	}
+
+	tokenProvider.mu.Lock()
+	tokenProvider.acquiring = false
+	tokenProvider.cond.Broadcast()
+	tokenProvider.mu.Unlock()
+}
+
+// ExpireToken invalidates the cached token so that the next GetAccessToken
+// call is treated as hard-expired and triggers a synchronous refresh.
+// Callers that observe a 401/invalid_token from the upstream should call
+// this before retrying with ForceRefresh.
+func (tokenProvider *tokenProvider) ExpireToken() {
+	tokenProvider.mu.Lock()
+	defer tokenProvider.mu.Unlock()
+	tokenProvider.token = ""
+	tokenProvider.expiresOn = time.Time{}
+}
+
+// ForceRefresh immediately refreshes the cached token instead of waiting for
+// it to expire, single-flighting onto any refresh already in progress, and
+// returns the new token. The caller's ctx is threaded into the credential
+// call so a 401-retry can bound the refresh with its own deadline/
+// cancellation instead of inheriting the provider's background context.
+func (tokenProvider *tokenProvider) ForceRefresh(ctx context.Context) (string, error) {
+	tokenProvider.mu.Lock()
+	joined := false
+	for tokenProvider.acquiring {
+		joined = true
+		tokenProvider.cond.Wait()
+	}
+	if joined && tokenProvider.lastError == nil && tokenProvider.hasValidTokenLocked(time.Now().UTC()) {
+		// The refresh that was already in flight succeeded; reuse its token.
+		token := tokenProvider.token
+		tokenProvider.mu.Unlock()
+		return token, nil
+	}
+	tokenProvider.acquiring = true
+	tokenProvider.mu.Unlock()
+
+	err := tokenProvider.refreshAADToken(ctx)
+
+	tokenProvider.mu.Lock()
+	tokenProvider.acquiring = false
+	tokenProvider.cond.Broadcast()
+	token := tokenProvider.token
+	tokenProvider.mu.Unlock()
+
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// GetAuxiliaryTokens returns the tokens minted for the auxiliary tenants, in the
+// order they were configured, for use in the x-ms-authorization-auxiliary header.
+func (tokenProvider *tokenProvider) GetAuxiliaryTokens() ([]string, error) {
+	tokens := make([]string, 0, len(tokenProvider.auxiliaryTokens))
+	for _, aux := range tokenProvider.auxiliaryTokens {
+		aux.mu.Lock()
+		token, err := aux.token, aux.lastError
+		aux.mu.Unlock()
+
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, nil
 }
 
-func (tokenProvider *tokenProvider) refreshAADToken() error {
+// refreshAADToken fetches a fresh primary token from the credential and
+// updates the cached record. It deliberately does not hold tokenProvider.mu
+// across the credential call: single-flighting is the caller's
+// responsibility (via the acquiring flag and cond variable), so that a slow
+// AAD round-trip never blocks unrelated field reads. parentCtx carries the
+// caller's deadline/cancellation into the credential call - periodic/
+// background callers pass tokenProvider.ctx, while ForceRefresh threads
+// through the caller-supplied context so a 401-retry can bound the retry.
+func (tokenProvider *tokenProvider) refreshAADToken(parentCtx context.Context) error {
 	// Record traces
-	ctx, span := otel.Tracer(constants.SERVICE_TELEMETRY_KEY).Start(tokenProvider.ctx, "refreshAADToken")
+	ctx, span := otel.Tracer(constants.SERVICE_TELEMETRY_KEY).Start(parentCtx, "refreshAADToken")
 	defer span.End()
 
 	// Telemetry attributes
@@ -76,6 +582,10 @@ func (tokenProvider *tokenProvider) refreshAADToken() error {
 	intrument, _ := meter.Int64Counter(constants.METRIC_TOKEN_REFRESH_TOTAL)
 
 	accessToken, err := tokenProvider.credentialClient.GetToken(ctx, *tokenProvider.options)
+
+	tokenProvider.mu.Lock()
+	defer tokenProvider.mu.Unlock()
+
 	if err != nil {
 		attributes = append(attributes, attribute.Bool("is_success", false))
 		span.SetAttributes(attributes...)
@@ -95,48 +605,134 @@ func (tokenProvider *tokenProvider) refreshAADToken() error {
 	attributes = append(attributes, attribute.Bool("is_success", true))
 	intrument.Add(ctx, 1)
 
-	tokenProvider.setToken(ctx, accessToken.Token)
-	tokenProvider.updateRefreshDuration(accessToken)
+	tokenProvider.token = accessToken.Token
+	tokenProvider.expiresOn = accessToken.ExpiresOn.UTC()
+	tokenProvider.proactiveRefreshAt = tokenProvider.getRefreshDuration(accessToken)
+
+	if err := tokenProvider.persistTokenToDiskCache(); err != nil {
+		span.RecordError(err)
+	}
 
 	attributes = append(attributes,
 		attribute.String("token.expiry_timestamp", accessToken.ExpiresOn.UTC().String()),
-		attribute.String("tokenrefresh.next_refresh_timestamp", time.Now().Add(tokenProvider.refreshDuration).UTC().String()),
-		attribute.String("tokenrefresh.refresh_duration", tokenProvider.refreshDuration.String()),
+		attribute.String("tokenrefresh.next_refresh_timestamp", tokenProvider.proactiveRefreshAt.String()),
 	)
 	span.SetAttributes(attributes...)
 	return nil
 }
 
-func (tokenProvider *tokenProvider) periodicallyRefreshClientToken(logger contracts.ILogger) error {
-	defer utils.HandlePanic("periodicallyRefreshClientToken")
+// refreshAuxiliaryToken fetches a fresh token for a single auxiliary tenant. It
+// mirrors refreshAADToken but tags traces and metrics with the tenant_id so that
+// per-tenant refresh health can be monitored independently of the primary token.
+func (tokenProvider *tokenProvider) refreshAuxiliaryToken(aux *auxiliaryToken) error {
+	// Record traces
+	ctx, span := otel.Tracer(constants.SERVICE_TELEMETRY_KEY).Start(tokenProvider.ctx, "refreshAuxiliaryToken")
+	defer span.End()
+
+	// Telemetry attributes
+	attributes := []attribute.KeyValue{attribute.String("tenant_id", aux.tenantID)}
+
+	// Record metrics
+	// token_refresh_total{is_success, tenant_id}
+	meter := otel.Meter(constants.SERVICE_TELEMETRY_KEY)
+	intrument, _ := meter.Int64Counter(constants.METRIC_TOKEN_REFRESH_TOTAL)
+
+	accessToken, err := tokenProvider.credentialClient.GetToken(ctx, *aux.options)
+	if err != nil {
+		attributes = append(attributes, attribute.Bool("is_success", false))
+		span.SetAttributes(attributes...)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to refresh auxiliary token")
+		intrument.Add(ctx, 1, metric.WithAttributes(attribute.String("tenant_id", aux.tenantID)))
+
+		aux.mu.Lock()
+		aux.lastError = err
+		aux.mu.Unlock()
+
+		return err
+	}
+
+	attributes = append(attributes, attribute.Bool("is_success", true))
+	intrument.Add(ctx, 1, metric.WithAttributes(attribute.String("tenant_id", aux.tenantID)))
+
+	earliestRefresh := tokenProvider.getRefreshDuration(accessToken)
+
+	aux.mu.Lock()
+	aux.lastError = nil
+	aux.token = accessToken.Token
+	aux.expiresOn = accessToken.ExpiresOn.UTC()
+	aux.refreshDuration = earliestRefresh.Sub(time.Now().UTC())
+	aux.mu.Unlock()
+
+	attributes = append(attributes,
+		attribute.String("token.expiry_timestamp", accessToken.ExpiresOn.UTC().String()),
+		attribute.String("tokenrefresh.next_refresh_timestamp", time.Now().Add(aux.refreshDuration).UTC().String()),
+		attribute.String("tokenrefresh.refresh_duration", aux.refreshDuration.String()),
+	)
+	span.SetAttributes(attributes...)
+	return nil
+}
+
+// periodicallyRefreshAuxiliaryToken runs a dedicated refresh loop for a single
+// auxiliary tenant's token, independent of the primary token's refresh
+// cadence. On failure it retries with decorrelated-jitter backoff, bounded by
+// the token's remaining validity, instead of giving up and exiting the
+// goroutine after a single failed attempt.
+func (tokenProvider *tokenProvider) periodicallyRefreshAuxiliaryToken(aux *auxiliaryToken, logger contracts.ILogger) error {
+	defer utils.HandlePanic("periodicallyRefreshAuxiliaryToken")
+
+	meter := otel.Meter(constants.SERVICE_TELEMETRY_KEY)
+	retryCounter, _ := meter.Int64Counter(constants.METRIC_TOKEN_REFRESH_RETRY_TOTAL)
 
 	for {
 		select {
 		case <-tokenProvider.ctx.Done():
 			return nil
-		case <-time.After(tokenProvider.refreshDuration):
-			err := tokenProvider.refreshAADToken()
-			if err != nil {
-				tokenProvider.refreshDuration = time.Duration(constants.TIME_5_MINUTES)
-				logger.Error("Failed to refresh token, retry in 5 minutes", err)
-				return errors.New("Failed to refresh token: " + err.Error())
+		case <-time.After(aux.refreshDuration):
+			backoff := time.Duration(0)
+			attempt := 0
+
+			for {
+				err := tokenProvider.refreshAuxiliaryToken(aux)
+				if err == nil {
+					break
+				}
+
+				aux.mu.Lock()
+				remaining := time.Until(aux.expiresOn)
+				aux.mu.Unlock()
+				if remaining <= 0 {
+					logger.Error("Auxiliary token for tenant "+aux.tenantID+" expired while refresh retries were failing", err)
+					aux.refreshDuration = constants.TIME_1_MINUTES
+					break
+				}
+
+				attempt++
+				backoff = nextRefreshBackoff(backoff)
+				if backoff > remaining {
+					backoff = remaining
+				}
+
+				_, span := otel.Tracer(constants.SERVICE_TELEMETRY_KEY).Start(tokenProvider.ctx, "refreshAuxiliaryToken.retry")
+				span.SetAttributes(
+					attribute.String("tenant_id", aux.tenantID),
+					attribute.Int("attempt", attempt),
+					attribute.String("backoff_duration", backoff.String()),
+				)
+				span.RecordError(err)
+				span.End()
+				retryCounter.Add(tokenProvider.ctx, 1, metric.WithAttributes(
+					attribute.String("tenant_id", aux.tenantID),
+					attribute.Int("attempt", attempt),
+				))
+
+				logger.Error("Failed to refresh auxiliary token for tenant "+aux.tenantID+", retrying with backoff", err)
+				time.Sleep(backoff)
 			}
 		}
 	}
 }
 
-func (tokenProvider *tokenProvider) setToken(ctx context.Context, token string) {
-	var V atomic.Value
-	V.Store(token)
-	tokenProvider.token = V.Load().(string)
-}
-
-func (tokenProvider *tokenProvider) updateRefreshDuration(accessToken azcore.AccessToken) error {
-	earlistTime := tokenProvider.getRefreshDuration(accessToken)
-	tokenProvider.refreshDuration = earlistTime.Sub(time.Now().UTC())
-	return nil
-}
-
 func (tokenProvider *tokenProvider) getRefreshDuration(accessToken azcore.AccessToken) time.Time {
 	tokenExpiryTimestamp := accessToken.ExpiresOn.UTC()
 	userConfiguredTimeFromNow := time.Now().UTC().Add(time.Duration(100-tokenProvider.userConfiguredDurationPercentage) * accessToken.ExpiresOn.Sub(time.Now()) / 100)
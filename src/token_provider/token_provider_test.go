@@ -0,0 +1,116 @@
+package token_provider
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// fakeCredential is a minimal azcore.TokenCredential that counts calls and can
+// be configured to fail, so tests can assert how many times the underlying
+// credential was actually invoked.
+type fakeCredential struct {
+	mu        sync.Mutex
+	calls     int
+	delay     time.Duration
+	err       error
+	token     string
+	expiresOn time.Time
+}
+
+func (f *fakeCredential) GetToken(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	f.mu.Lock()
+	f.calls++
+	f.mu.Unlock()
+
+	if f.delay > 0 {
+		time.Sleep(f.delay)
+	}
+	if f.err != nil {
+		return azcore.AccessToken{}, f.err
+	}
+	return azcore.AccessToken{Token: f.token, ExpiresOn: f.expiresOn}, nil
+}
+
+func (f *fakeCredential) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func newTestTokenProvider(cred azcore.TokenCredential) *tokenProvider {
+	tp := &tokenProvider{
+		ctx:                              context.Background(),
+		credentialClient:                 cred,
+		options:                          &policy.TokenRequestOptions{Scopes: []string{"https://example.com/.default"}},
+		userConfiguredDurationPercentage: 50,
+	}
+	tp.cond = sync.NewCond(&tp.mu)
+	return tp
+}
+
+func TestGetAccessToken_SingleFlightsConcurrentCallers(t *testing.T) {
+	cred := &fakeCredential{
+		token:     "fresh-token",
+		expiresOn: time.Now().Add(time.Hour),
+		delay:     20 * time.Millisecond,
+	}
+	tp := newTestTokenProvider(cred)
+
+	const callers = 50
+	var wg sync.WaitGroup
+	wg.Add(callers)
+
+	for i := 0; i < callers; i++ {
+		go func() {
+			defer wg.Done()
+			token, err := tp.GetAccessToken()
+			if err != nil {
+				t.Errorf("GetAccessToken: unexpected error: %v", err)
+				return
+			}
+			if token != "fresh-token" {
+				t.Errorf("GetAccessToken: got token %q, want %q", token, "fresh-token")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := cred.callCount(); got != 1 {
+		t.Fatalf("expected concurrent callers to single-flight onto one credential call, got %d calls", got)
+	}
+}
+
+func TestGetAccessToken_ReturnsValidTokenDespiteStaleRefreshError(t *testing.T) {
+	tp := newTestTokenProvider(&fakeCredential{})
+	tp.token = "still-valid"
+	tp.expiresOn = time.Now().Add(time.Hour)
+	tp.proactiveRefreshAt = time.Now().Add(30 * time.Minute)
+	tp.lastError = errors.New("most recent background refresh failed")
+
+	token, err := tp.GetAccessToken()
+	if err != nil {
+		t.Fatalf("GetAccessToken: got error %v for a still-valid cached token, want nil", err)
+	}
+	if token != "still-valid" {
+		t.Fatalf("GetAccessToken: got token %q, want %q", token, "still-valid")
+	}
+}
+
+func TestGetAccessToken_PropagatesErrorWhenNoCachedToken(t *testing.T) {
+	wantErr := errors.New("AAD is unreachable")
+	tp := newTestTokenProvider(&fakeCredential{err: wantErr})
+
+	token, err := tp.GetAccessToken()
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("GetAccessToken: got error %v, want %v", err, wantErr)
+	}
+	if token != "" {
+		t.Fatalf("GetAccessToken: got token %q, want empty string on failure", token)
+	}
+}